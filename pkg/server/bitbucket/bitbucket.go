@@ -0,0 +1,280 @@
+// Package bitbucket implements forge.Provider against the Bitbucket
+// Server/Data Center REST API. (Bitbucket Cloud uses a different API and is
+// not handled here.)
+package bitbucket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/servers/pkg/server/forge"
+)
+
+// Bitbucket Server computes pull-request mergeability asynchronously after
+// creation, so MergeBranch polls the merge-check endpoint a few times before
+// attempting the merge rather than racing an in-progress check into a
+// spurious ErrPullRequestNotMergeable.
+const (
+	mergeabilityPollAttempts = 5
+	mergeabilityPollInterval = 500 * time.Millisecond
+)
+
+// Server implements forge.Provider against a Bitbucket Server/Data Center
+// instance's REST API.
+type Server struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+var _ forge.Provider = (*Server)(nil)
+
+// NewServer builds a Server talking to the Bitbucket Server instance at
+// baseURL (e.g. "https://bitbucket.example.com"), authenticating with an
+// HTTP access token.
+func NewServer(baseURL string, token string) *Server {
+	return &Server{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		token:      token,
+		httpClient: http.DefaultClient,
+	}
+}
+
+type ref struct {
+	ID           string `json:"id"`
+	LatestCommit string `json:"latestCommit"`
+}
+
+type pullRequest struct {
+	ID          int    `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	State       string `json:"state"`
+	FromRef     ref    `json:"fromRef"`
+	ToRef       ref    `json:"toRef"`
+	Version     int    `json:"version"`
+	Links       struct {
+		Self []struct {
+			Href string `json:"href"`
+		} `json:"self"`
+	} `json:"links"`
+}
+
+func (pr *pullRequest) toPullRequest() *forge.PullRequest {
+	htmlURL := ""
+	if len(pr.Links.Self) > 0 {
+		htmlURL = pr.Links.Self[0].Href
+	}
+	return &forge.PullRequest{
+		Number:  pr.ID,
+		Title:   pr.Title,
+		Body:    pr.Description,
+		State:   pr.State,
+		HTMLURL: htmlURL,
+		Base:    strings.TrimPrefix(pr.ToRef.ID, "refs/heads/"),
+		Head:    strings.TrimPrefix(pr.FromRef.ID, "refs/heads/"),
+		Merged:  pr.State == "MERGED",
+	}
+}
+
+func (s *Server) do(ctx context.Context, method string, path string, body interface{}, out interface{}) error {
+	var reqBody *bytes.Buffer
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request body: %v", err)
+		}
+		reqBody = bytes.NewBuffer(b)
+	} else {
+		reqBody = bytes.NewBuffer(nil)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, s.baseURL+"/rest/api/1.0/"+path, reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+s.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request to %s failed: %v", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		switch resp.StatusCode {
+		case 404, 409:
+			return fmt.Errorf("%w: %s returned %d", forge.ErrPullRequestNotMergeable, path, resp.StatusCode)
+		case 403:
+			return fmt.Errorf("%w: %s returned %d", forge.ErrMergePermissionDenied, path, resp.StatusCode)
+		}
+		return fmt.Errorf("%s returned %d", path, resp.StatusCode)
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// projectPath builds the "projects/{project}/repos/{repo}" path segment;
+// owner maps onto the Bitbucket project key.
+func projectPath(owner string, repo string) string {
+	return fmt.Sprintf("projects/%s/repos/%s", owner, repo)
+}
+
+// CreatePullRequest opens a pull request from input.Head into input.Base.
+func (s *Server) CreatePullRequest(ctx context.Context, owner string, repo string, input forge.PullRequestInput) (*forge.PullRequest, error) {
+	var pr pullRequest
+	err := s.do(ctx, http.MethodPost, projectPath(owner, repo)+"/pull-requests", map[string]interface{}{
+		"title":       input.Title,
+		"description": input.Body,
+		"fromRef":     map[string]string{"id": "refs/heads/" + input.Head},
+		"toRef":       map[string]string{"id": "refs/heads/" + input.Base},
+	}, &pr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pull request: %v", err)
+	}
+	return pr.toPullRequest(), nil
+}
+
+// GetPullRequest fetches a single pull request by ID.
+func (s *Server) GetPullRequest(ctx context.Context, owner string, repo string, number int) (*forge.PullRequest, error) {
+	var pr pullRequest
+	err := s.do(ctx, http.MethodGet, fmt.Sprintf("%s/pull-requests/%d", projectPath(owner, repo), number), nil, &pr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request: %v", err)
+	}
+	return pr.toPullRequest(), nil
+}
+
+// ListPullRequests lists pull requests filtered by state ("OPEN", "MERGED",
+// "DECLINED", or "ALL"). GitHub's lowercase "open"/"closed" are accepted too
+// so callers can pass either convention: "open" is upper-cased, and "closed"
+// returns the merged and declined pull requests.
+func (s *Server) ListPullRequests(ctx context.Context, owner string, repo string, state string) ([]*forge.PullRequest, error) {
+	state = strings.ToUpper(state)
+	closedOnly := false
+	if state == "CLOSED" {
+		state = "ALL"
+		closedOnly = true
+	}
+
+	var page struct {
+		Values []pullRequest `json:"values"`
+	}
+	err := s.do(ctx, http.MethodGet, fmt.Sprintf("%s/pull-requests?state=%s", projectPath(owner, repo), state), nil, &page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull requests: %v", err)
+	}
+	out := make([]*forge.PullRequest, 0, len(page.Values))
+	for i := range page.Values {
+		if closedOnly && page.Values[i].State == "OPEN" {
+			continue
+		}
+		out = append(out, page.Values[i].toPullRequest())
+	}
+	return out, nil
+}
+
+// MergePullRequest merges the given pull request. Bitbucket Server has no
+// concept of merge method per request (it's configured per-repo), so
+// mergeMethod is validated but otherwise informational. Bitbucket Server
+// also has no separate commit-title field, so commitTitle is prepended to
+// commitMessage the way GitHub concatenates title and body for squash
+// merges. If sha is non-empty, it is compared against the source branch's
+// current tip (fetched via a fresh lookup of the pull request) and the merge is
+// refused if they don't match, guarding against merging a commit the caller
+// never saw.
+func (s *Server) MergePullRequest(ctx context.Context, owner string, repo string, number int, commitTitle string, commitMessage string, mergeMethod string, sha string) error {
+	switch mergeMethod {
+	case "merge", "squash", "rebase":
+	default:
+		return fmt.Errorf("invalid merge method %q: must be \"merge\", \"squash\", or \"rebase\"", mergeMethod)
+	}
+
+	var pr pullRequest
+	if err := s.do(ctx, http.MethodGet, fmt.Sprintf("%s/pull-requests/%d", projectPath(owner, repo), number), nil, &pr); err != nil {
+		return fmt.Errorf("failed to look up pull request before merge: %v", err)
+	}
+	if sha != "" && pr.FromRef.LatestCommit != sha {
+		return fmt.Errorf("%w: source branch is at %s, expected %s", forge.ErrPullRequestNotMergeable, pr.FromRef.LatestCommit, sha)
+	}
+
+	message := commitMessage
+	if commitTitle != "" {
+		message = commitTitle + "\n\n" + commitMessage
+	}
+
+	return s.do(ctx, http.MethodPost, fmt.Sprintf("%s/pull-requests/%d/merge?version=%d", projectPath(owner, repo), number, pr.Version), map[string]string{
+		"message": message,
+	}, nil)
+}
+
+// MergeBranch merges head into base. Bitbucket Server has no single-shot
+// branch-to-branch merge endpoint, so this opens a pull request from head
+// into base and immediately merges it. Bitbucket Server computes
+// mergeability asynchronously after a pull request is created, so this
+// polls the merge-check endpoint for up to mergeabilityPollAttempts before
+// attempting the merge; if the check hasn't settled by then, the merge is
+// attempted anyway and may fail with forge.ErrPullRequestNotMergeable.
+func (s *Server) MergeBranch(ctx context.Context, owner string, repo string, base string, head string, commitMessage string) (string, error) {
+	var pr pullRequest
+	err := s.do(ctx, http.MethodPost, projectPath(owner, repo)+"/pull-requests", map[string]interface{}{
+		"title":   commitMessage,
+		"fromRef": map[string]string{"id": "refs/heads/" + head},
+		"toRef":   map[string]string{"id": "refs/heads/" + base},
+	}, &pr)
+	if err != nil {
+		return "", fmt.Errorf("failed to open pull request for %s into %s: %v", head, base, err)
+	}
+
+	var check struct {
+		CanMerge   bool `json:"canMerge"`
+		Conflicted bool `json:"conflicted"`
+	}
+	for attempt := 0; attempt < mergeabilityPollAttempts && !check.CanMerge; attempt++ {
+		if err := s.do(ctx, http.MethodGet, fmt.Sprintf("%s/pull-requests/%d/merge", projectPath(owner, repo), pr.ID), nil, &check); err != nil {
+			return "", fmt.Errorf("failed to poll merge status for %s into %s: %v", head, base, err)
+		}
+		if check.Conflicted {
+			return "", fmt.Errorf("%w: %s conflicts with %s", forge.ErrPullRequestNotMergeable, head, base)
+		}
+		if check.CanMerge {
+			break
+		}
+		time.Sleep(mergeabilityPollInterval)
+	}
+
+	var merged struct {
+		Properties struct {
+			MergeCommit struct {
+				ID string `json:"id"`
+			} `json:"mergeCommit"`
+		} `json:"properties"`
+	}
+	err = s.do(ctx, http.MethodPost, fmt.Sprintf("%s/pull-requests/%d/merge?version=%d", projectPath(owner, repo), pr.ID, pr.Version), map[string]string{
+		"message": commitMessage,
+	}, &merged)
+	if err != nil {
+		return "", fmt.Errorf("failed to merge %s into %s: %v", head, base, err)
+	}
+
+	return merged.Properties.MergeCommit.ID, nil
+}
+
+// SyncFork is not supported: Bitbucket Server has no REST endpoint
+// equivalent to GitHub's "Sync fork", so it always returns a
+// *forge.UnsupportedError. MergeBranch merges two branches of the same
+// repository and does not reach into the upstream repository either.
+func (s *Server) SyncFork(ctx context.Context, owner string, repo string, branch string) (mergeType string, baseBranch string, err error) {
+	return "", "", &forge.UnsupportedError{Kind: forge.KindBitbucketServer, Operation: "SyncFork"}
+}
@@ -0,0 +1,98 @@
+// Package forge defines the provider-agnostic surface that every Git forge
+// backend (GitHub, GitHub Enterprise Server, GitLab, Bitbucket Server) must
+// implement. The MCP tool registrations are written against Provider, so the
+// same tools work regardless of which forge a user points the server at.
+package forge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrPullRequestNotMergeable is returned when the forge refuses a merge
+// because the pull/merge request isn't in a mergeable state yet (e.g. the
+// head branch moved, checks are still pending, or there's a conflict).
+var ErrPullRequestNotMergeable = errors.New("pull request is not mergeable")
+
+// ErrMergePermissionDenied is returned when the authenticated user lacks
+// permission to merge the pull/merge request.
+var ErrMergePermissionDenied = errors.New("permission denied to merge pull request")
+
+// ErrNothingToMerge is returned by MergeBranch when base already contains
+// everything on head, so the forge performed no merge (e.g. GitHub's 204 "no
+// merge performed" response). Callers should treat this as success with no
+// new commit, not as a failure.
+var ErrNothingToMerge = errors.New("nothing to merge: base already contains head")
+
+// Kind identifies which forge backend a Provider talks to.
+type Kind string
+
+const (
+	KindGitHub           Kind = "github"
+	KindGitHubEnterprise Kind = "github-enterprise"
+	KindGitLab           Kind = "gitlab"
+	KindBitbucketServer  Kind = "bitbucket-server"
+)
+
+// PullRequestInput is the common set of fields needed to open a pull request
+// (GitHub/GHES), merge request (GitLab), or pull request (Bitbucket Server).
+type PullRequestInput struct {
+	Title string
+	Body  string
+	Base  string
+	Head  string
+}
+
+// PullRequest is the common shape a Provider normalizes its backend's
+// pull/merge request representation into.
+type PullRequest struct {
+	Number  int
+	Title   string
+	Body    string
+	State   string
+	HTMLURL string
+	Base    string
+	Head    string
+	Merged  bool
+}
+
+// Provider is implemented by each forge backend. Every method takes the
+// owner (GitHub/GHES) or project namespace (GitLab) or project key
+// (Bitbucket Server) as owner, and the repository/project slug as repo.
+//
+// GetPullRequestRaw (raw diff/patch text, GitHub/GHES only today) is
+// deliberately not part of this interface: GitLab and Bitbucket Server
+// expose differently-shaped diff APIs (structured hunks, not a single
+// unified-diff blob), so it currently lives only on the github package's
+// Server and can't be registered as an MCP tool uniformly across providers.
+type Provider interface {
+	CreatePullRequest(ctx context.Context, owner string, repo string, input PullRequestInput) (*PullRequest, error)
+	GetPullRequest(ctx context.Context, owner string, repo string, number int) (*PullRequest, error)
+	ListPullRequests(ctx context.Context, owner string, repo string, state string) ([]*PullRequest, error)
+	MergePullRequest(ctx context.Context, owner string, repo string, number int, commitTitle string, commitMessage string, mergeMethod string, sha string) error
+	MergeBranch(ctx context.Context, owner string, repo string, base string, head string, commitMessage string) (sha string, err error)
+	SyncFork(ctx context.Context, owner string, repo string, branch string) (mergeType string, baseBranch string, err error)
+}
+
+// Config describes how to reach a forge backend: which Kind it is, its
+// BaseURL (ignored for Kind == KindGitHub, which always talks to
+// github.com), and the token used to authenticate.
+type Config struct {
+	Kind    Kind
+	BaseURL string
+	Token   string
+}
+
+// UnsupportedError is returned by a Provider method when the operation has
+// no equivalent on that backend (e.g. SyncFork on GitLab or Bitbucket
+// Server), so callers can distinguish "not implemented here"
+// from a real request failure.
+type UnsupportedError struct {
+	Kind      Kind
+	Operation string
+}
+
+func (e *UnsupportedError) Error() string {
+	return fmt.Sprintf("%s does not support %s", e.Kind, e.Operation)
+}
@@ -1,36 +1,249 @@
+// Package github implements forge.Provider against github.com and GitHub
+// Enterprise Server.
 package github
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"net/http"
+
 	"github.com/google/go-github/v60/github"
 	"golang.org/x/oauth2"
+
+	"github.com/modelcontextprotocol/servers/pkg/server/forge"
 )
 
+// maxPullRequestRawSize bounds how much diff/patch text GetPullRequestRaw
+// will return, so a huge pull request doesn't silently blow out an LLM's
+// context window.
+const maxPullRequestRawSize = 5 * 1024 * 1024 // 5 MB
+
+// limitedBuffer is an io.Writer that stops accepting bytes once it has
+// received more than limit, so a response body is never fully buffered in
+// memory just to be rejected afterward.
+type limitedBuffer struct {
+	buf   bytes.Buffer
+	limit int
+}
+
+func (w *limitedBuffer) Write(p []byte) (int, error) {
+	if w.buf.Len()+len(p) > w.limit {
+		return 0, fmt.Errorf("response exceeds %d byte limit", w.limit)
+	}
+	return w.buf.Write(p)
+}
+
+// Server implements forge.Provider against the GitHub (or GitHub Enterprise
+// Server) REST API.
 type Server struct {
 	client *github.Client
 }
 
-func NewServer(token string) *Server {
+var _ forge.Provider = (*Server)(nil)
+
+func newClient(token string) *github.Client {
 	ctx := context.Background()
 	ts := oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: token},
 	)
 	tc := oauth2.NewClient(ctx, ts)
-	client := github.NewClient(tc)
-	return &Server{client: client}
+	return github.NewClient(tc)
+}
+
+// NewServer builds a Server that talks to github.com.
+func NewServer(token string) *Server {
+	return &Server{client: newClient(token)}
+}
+
+// NewEnterpriseServer builds a Server that talks to a GitHub Enterprise
+// Server instance at baseURL (e.g. "https://github.example.com/api/v3/").
+// uploadURL is the instance's uploads endpoint; both URLs are handed to
+// go-github's WithEnterpriseURLs as given.
+func NewEnterpriseServer(baseURL string, uploadURL string, token string) (*Server, error) {
+	client, err := newClient(token).WithEnterpriseURLs(baseURL, uploadURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure GitHub Enterprise client: %v", err)
+	}
+	return &Server{client: client}, nil
+}
+
+func toPullRequest(pr *github.PullRequest) *forge.PullRequest {
+	return &forge.PullRequest{
+		Number:  pr.GetNumber(),
+		Title:   pr.GetTitle(),
+		Body:    pr.GetBody(),
+		State:   pr.GetState(),
+		HTMLURL: pr.GetHTMLURL(),
+		Base:    pr.GetBase().GetRef(),
+		Head:    pr.GetHead().GetRef(),
+		Merged:  pr.GetMerged(),
+	}
+}
+
+// CreatePullRequest opens a pull request from input.Head into input.Base.
+func (s *Server) CreatePullRequest(ctx context.Context, owner string, repo string, input forge.PullRequestInput) (*forge.PullRequest, error) {
+	pr, _, err := s.client.PullRequests.Create(ctx, owner, repo, &github.NewPullRequest{
+		Title: &input.Title,
+		Body:  &input.Body,
+		Base:  &input.Base,
+		Head:  &input.Head,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pull request: %v", err)
+	}
+	return toPullRequest(pr), nil
+}
+
+// GetPullRequest fetches a single pull request by number.
+func (s *Server) GetPullRequest(ctx context.Context, owner string, repo string, number int) (*forge.PullRequest, error) {
+	pr, _, err := s.client.PullRequests.Get(ctx, owner, repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request: %v", err)
+	}
+	return toPullRequest(pr), nil
+}
+
+// ListPullRequests lists pull requests filtered by state ("open", "closed",
+// or "all").
+func (s *Server) ListPullRequests(ctx context.Context, owner string, repo string, state string) ([]*forge.PullRequest, error) {
+	prs, _, err := s.client.PullRequests.List(ctx, owner, repo, &github.PullRequestListOptions{State: state})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull requests: %v", err)
+	}
+	out := make([]*forge.PullRequest, 0, len(prs))
+	for _, pr := range prs {
+		out = append(out, toPullRequest(pr))
+	}
+	return out, nil
 }
 
-func (s *Server) MergePullRequest(ctx context.Context, owner string, repo string, number int, commitTitle string, commitMessage string) error {
+// MergePullRequest merges the given pull request using mergeMethod ("merge",
+// "squash", or "rebase"). If sha is non-empty, it is passed through so GitHub
+// rejects the merge when the PR's head has moved since the caller last
+// inspected it, guarding against merging a commit the caller never saw.
+func (s *Server) MergePullRequest(ctx context.Context, owner string, repo string, number int, commitTitle string, commitMessage string, mergeMethod string, sha string) error {
+	switch mergeMethod {
+	case "merge", "squash", "rebase":
+	default:
+		return fmt.Errorf("invalid merge method %q: must be \"merge\", \"squash\", or \"rebase\"", mergeMethod)
+	}
+
 	opts := &github.PullRequestOptions{
 		CommitTitle: commitTitle,
-		MergeMethod: "merge",
+		MergeMethod: mergeMethod,
+		SHA:         sha,
 	}
-	
-	_, _, err := s.client.PullRequests.Merge(ctx, owner, repo, number, commitMessage, opts)
+
+	_, resp, err := s.client.PullRequests.Merge(ctx, owner, repo, number, commitMessage, opts)
 	if err != nil {
+		if resp != nil {
+			switch resp.StatusCode {
+			case 405, 409:
+				return fmt.Errorf("%w: %v", forge.ErrPullRequestNotMergeable, err)
+			case 403:
+				return fmt.Errorf("%w: %v", forge.ErrMergePermissionDenied, err)
+			}
+		}
 		return fmt.Errorf("failed to merge pull request: %v", err)
 	}
-	
+
 	return nil
-}
\ No newline at end of file
+}
+
+// MergeBranch merges head into base, independent of any pull request. It is
+// the programmatic equivalent of "Create a merge commit" in the GitHub UI and
+// is useful for keeping a long-lived branch (e.g. a feature branch) in sync
+// with upstream without going through a PR. It returns the resulting merge
+// commit SHA, or forge.ErrNothingToMerge if base already contains head
+// (GitHub reports this as a 204 with no commit body).
+func (s *Server) MergeBranch(ctx context.Context, owner string, repo string, base string, head string, commitMessage string) (string, error) {
+	commit, resp, err := s.client.Repositories.Merge(ctx, owner, repo, &github.RepositoryMergeRequest{
+		Base:          &base,
+		Head:          &head,
+		CommitMessage: &commitMessage,
+	})
+	if err != nil {
+		if resp != nil {
+			switch resp.StatusCode {
+			case 409:
+				return "", fmt.Errorf("merge conflict between %s and %s: %v", base, head, err)
+			case 404:
+				return "", fmt.Errorf("branch not found: %s or %s does not exist: %v", base, head, err)
+			}
+		}
+		return "", fmt.Errorf("failed to merge branch %s into %s: %v", head, base, err)
+	}
+	if resp.StatusCode == http.StatusNoContent {
+		return "", forge.ErrNothingToMerge
+	}
+
+	return commit.GetSHA(), nil
+}
+
+// repoMergeUpstreamResult mirrors the response of the "Sync a fork branch
+// with the upstream repository" endpoint. go-github v60 does not yet expose
+// Repositories.MergeUpstream, so we decode the response ourselves.
+type repoMergeUpstreamResult struct {
+	Message    string `json:"message"`
+	MergeType  string `json:"merge_type"`
+	BaseBranch string `json:"base_branch"`
+}
+
+// SyncFork syncs branch in the fork owner/repo with its parent, equivalent
+// to clicking "Sync fork" in the GitHub UI. The returned mergeType is one of
+// "fast-forward", "merge", or "none" and is surfaced verbatim so the caller
+// can decide whether a fast-forward was possible or a reconciliation PR is
+// needed instead.
+//
+// This was originally added as SyncForkWithUpstream; it was renamed to
+// SyncFork so Server satisfies forge.Provider, whose method set is shared
+// across backends and can't carry a GitHub-specific name.
+func (s *Server) SyncFork(ctx context.Context, owner string, repo string, branch string) (mergeType string, baseBranch string, err error) {
+	u := fmt.Sprintf("repos/%s/%s/merge-upstream", owner, repo)
+	req, err := s.client.NewRequest("POST", u, &struct {
+		Branch string `json:"branch"`
+	}{Branch: branch})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to build sync-fork request: %v", err)
+	}
+
+	var result repoMergeUpstreamResult
+	_, err = s.client.Do(ctx, req, &result)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to sync fork branch %s with upstream: %v", branch, err)
+	}
+
+	return result.MergeType, result.BaseBranch, nil
+}
+
+// GetPullRequestRaw fetches a pull request as unified diff or patch text
+// (format is "diff" or "patch"), so a caller can hand it straight to an LLM
+// for review without cloning the repo. The response is size-guarded against
+// maxPullRequestRawSize rather than decoded as JSON.
+func (s *Server) GetPullRequestRaw(ctx context.Context, owner string, repo string, number int, format string) (string, error) {
+	var accept string
+	switch format {
+	case "diff":
+		accept = "application/vnd.github.v3.diff"
+	case "patch":
+		accept = "application/vnd.github.v3.patch"
+	default:
+		return "", fmt.Errorf("invalid format %q: must be \"diff\" or \"patch\"", format)
+	}
+
+	u := fmt.Sprintf("repos/%s/%s/pulls/%d", owner, repo, number)
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to build pull request %s request: %v", format, err)
+	}
+	req.Header.Set("Accept", accept)
+
+	w := &limitedBuffer{limit: maxPullRequestRawSize}
+	_, err = s.client.Do(ctx, req, w)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch pull request %s: %v", format, err)
+	}
+
+	return w.buf.String(), nil
+}
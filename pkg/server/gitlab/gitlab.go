@@ -0,0 +1,240 @@
+// Package gitlab implements forge.Provider against the GitLab REST API
+// (v4), for both gitlab.com and self-managed instances.
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/servers/pkg/server/forge"
+)
+
+// GitLab computes merge-request mergeability asynchronously after creation,
+// so MergeBranch polls the merge request a few times before attempting the
+// merge rather than racing a "checking" status into a spurious
+// ErrPullRequestNotMergeable.
+const (
+	mergeabilityPollAttempts = 5
+	mergeabilityPollInterval = 500 * time.Millisecond
+)
+
+// Server implements forge.Provider against a GitLab instance's REST API.
+type Server struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+var _ forge.Provider = (*Server)(nil)
+
+// NewServer builds a Server talking to the GitLab instance at baseURL (e.g.
+// "https://gitlab.com" or "https://gitlab.example.com"), authenticating
+// with a personal or project access token.
+func NewServer(baseURL string, token string) *Server {
+	return &Server{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		token:      token,
+		httpClient: http.DefaultClient,
+	}
+}
+
+// project encodes "owner/repo" as the URL-encoded path GitLab's API expects
+// in place of a numeric project ID.
+func project(owner string, repo string) string {
+	return url.PathEscape(owner + "/" + repo)
+}
+
+type mergeRequest struct {
+	IID          int    `json:"iid"`
+	Title        string `json:"title"`
+	Description  string `json:"description"`
+	State        string `json:"state"`
+	WebURL       string `json:"web_url"`
+	SourceBranch string `json:"source_branch"`
+	TargetBranch string `json:"target_branch"`
+	MergedAt     string `json:"merged_at"`
+	MergeStatus  string `json:"merge_status"`
+}
+
+func (mr *mergeRequest) toPullRequest() *forge.PullRequest {
+	return &forge.PullRequest{
+		Number:  mr.IID,
+		Title:   mr.Title,
+		Body:    mr.Description,
+		State:   mr.State,
+		HTMLURL: mr.WebURL,
+		Base:    mr.TargetBranch,
+		Head:    mr.SourceBranch,
+		Merged:  mr.MergedAt != "",
+	}
+}
+
+func (s *Server) do(ctx context.Context, method string, path string, body interface{}, out interface{}) error {
+	var reqBody *bytes.Buffer
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request body: %v", err)
+		}
+		reqBody = bytes.NewBuffer(b)
+	} else {
+		reqBody = bytes.NewBuffer(nil)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, s.baseURL+"/api/v4/"+path, reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %v", err)
+	}
+	req.Header.Set("PRIVATE-TOKEN", s.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request to %s failed: %v", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		switch resp.StatusCode {
+		case 405, 406, 409:
+			return fmt.Errorf("%w: %s returned %d", forge.ErrPullRequestNotMergeable, path, resp.StatusCode)
+		case 403:
+			return fmt.Errorf("%w: %s returned %d", forge.ErrMergePermissionDenied, path, resp.StatusCode)
+		}
+		return fmt.Errorf("%s returned %d", path, resp.StatusCode)
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// CreatePullRequest opens a merge request from input.Head into input.Base.
+func (s *Server) CreatePullRequest(ctx context.Context, owner string, repo string, input forge.PullRequestInput) (*forge.PullRequest, error) {
+	var mr mergeRequest
+	err := s.do(ctx, http.MethodPost, fmt.Sprintf("projects/%s/merge_requests", project(owner, repo)), map[string]string{
+		"title":         input.Title,
+		"description":   input.Body,
+		"source_branch": input.Head,
+		"target_branch": input.Base,
+	}, &mr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create merge request: %v", err)
+	}
+	return mr.toPullRequest(), nil
+}
+
+// GetPullRequest fetches a single merge request by its internal ID (iid).
+func (s *Server) GetPullRequest(ctx context.Context, owner string, repo string, number int) (*forge.PullRequest, error) {
+	var mr mergeRequest
+	err := s.do(ctx, http.MethodGet, fmt.Sprintf("projects/%s/merge_requests/%d", project(owner, repo), number), nil, &mr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merge request: %v", err)
+	}
+	return mr.toPullRequest(), nil
+}
+
+// ListPullRequests lists merge requests filtered by state ("opened",
+// "closed", "merged", or "all"). GitHub's "open"/"closed" are mapped onto
+// GitLab's "opened"/"closed" so callers can pass either convention.
+func (s *Server) ListPullRequests(ctx context.Context, owner string, repo string, state string) ([]*forge.PullRequest, error) {
+	if state == "open" {
+		state = "opened"
+	}
+
+	var mrs []mergeRequest
+	err := s.do(ctx, http.MethodGet, fmt.Sprintf("projects/%s/merge_requests?state=%s", project(owner, repo), url.QueryEscape(state)), nil, &mrs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merge requests: %v", err)
+	}
+	out := make([]*forge.PullRequest, 0, len(mrs))
+	for i := range mrs {
+		out = append(out, mrs[i].toPullRequest())
+	}
+	return out, nil
+}
+
+// MergePullRequest merges the given merge request. mergeMethod "squash" maps
+// to GitLab's squash option; "rebase" has no GitLab equivalent at merge time
+// (GitLab rebases via a separate endpoint before merging), so it is treated
+// the same as "merge". If sha is non-empty, it is sent as
+// sha so GitLab rejects the merge if the source branch has moved.
+func (s *Server) MergePullRequest(ctx context.Context, owner string, repo string, number int, commitTitle string, commitMessage string, mergeMethod string, sha string) error {
+	switch mergeMethod {
+	case "merge", "squash", "rebase":
+	default:
+		return fmt.Errorf("invalid merge method %q: must be \"merge\", \"squash\", or \"rebase\"", mergeMethod)
+	}
+
+	payload := map[string]interface{}{
+		"merge_commit_message": commitMessage,
+		"squash":               mergeMethod == "squash",
+	}
+	if commitTitle != "" {
+		payload["squash_commit_message"] = commitTitle
+	}
+	if sha != "" {
+		payload["sha"] = sha
+	}
+
+	return s.do(ctx, http.MethodPut, fmt.Sprintf("projects/%s/merge_requests/%d/merge", project(owner, repo), number), payload, nil)
+}
+
+// MergeBranch merges head into base. GitLab has no single-shot
+// branch-to-branch merge endpoint, so this opens a merge request from head
+// into base and immediately merges it, returning the resulting merge commit
+// SHA. GitLab computes mergeability asynchronously after a merge request is
+// created, so this polls the merge request's merge_status for up to
+// mergeabilityPollAttempts before attempting the merge; if it's still
+// "checking" by then, the merge is attempted anyway and may fail with
+// forge.ErrPullRequestNotMergeable.
+func (s *Server) MergeBranch(ctx context.Context, owner string, repo string, base string, head string, commitMessage string) (string, error) {
+	var mr mergeRequest
+	err := s.do(ctx, http.MethodPost, fmt.Sprintf("projects/%s/merge_requests", project(owner, repo)), map[string]string{
+		"title":         commitMessage,
+		"source_branch": head,
+		"target_branch": base,
+	}, &mr)
+	if err != nil {
+		return "", fmt.Errorf("failed to open merge request for %s into %s: %v", head, base, err)
+	}
+
+	for attempt := 0; attempt < mergeabilityPollAttempts && mr.MergeStatus != "can_be_merged"; attempt++ {
+		if mr.MergeStatus == "cannot_be_merged" {
+			return "", fmt.Errorf("%w: %s cannot be merged into %s", forge.ErrPullRequestNotMergeable, head, base)
+		}
+		time.Sleep(mergeabilityPollInterval)
+		if err := s.do(ctx, http.MethodGet, fmt.Sprintf("projects/%s/merge_requests/%d", project(owner, repo), mr.IID), nil, &mr); err != nil {
+			return "", fmt.Errorf("failed to poll merge status for %s into %s: %v", head, base, err)
+		}
+	}
+
+	var merged struct {
+		MergeCommitSHA string `json:"merge_commit_sha"`
+	}
+	err = s.do(ctx, http.MethodPut, fmt.Sprintf("projects/%s/merge_requests/%d/merge", project(owner, repo), mr.IID), map[string]interface{}{
+		"merge_commit_message": commitMessage,
+	}, &merged)
+	if err != nil {
+		return "", fmt.Errorf("failed to merge %s into %s: %v", head, base, err)
+	}
+
+	return merged.MergeCommitSHA, nil
+}
+
+// SyncFork is not supported: GitLab has no REST endpoint equivalent to
+// GitHub's "Sync fork", so it always returns a *forge.UnsupportedError.
+// MergeBranch merges two branches of the same project and does not reach
+// into the upstream project either.
+func (s *Server) SyncFork(ctx context.Context, owner string, repo string, branch string) (mergeType string, baseBranch string, err error) {
+	return "", "", &forge.UnsupportedError{Kind: forge.KindGitLab, Operation: "SyncFork"}
+}
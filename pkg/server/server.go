@@ -0,0 +1,39 @@
+// Package server builds a forge.Provider for whichever backend a user has
+// configured, so the same MCP tool registrations work against github.com,
+// GitHub Enterprise Server, GitLab, or Bitbucket Server.
+package server
+
+import (
+	"fmt"
+
+	"github.com/modelcontextprotocol/servers/pkg/server/bitbucket"
+	"github.com/modelcontextprotocol/servers/pkg/server/forge"
+	"github.com/modelcontextprotocol/servers/pkg/server/github"
+	"github.com/modelcontextprotocol/servers/pkg/server/gitlab"
+)
+
+// NewServer builds a forge.Provider for cfg.Kind. BaseURL is ignored for
+// KindGitHub, which always talks to github.com.
+func NewServer(cfg forge.Config) (forge.Provider, error) {
+	switch cfg.Kind {
+	case forge.KindGitHub:
+		return github.NewServer(cfg.Token), nil
+	case forge.KindGitHubEnterprise:
+		if cfg.BaseURL == "" {
+			return nil, fmt.Errorf("base URL is required for %s", cfg.Kind)
+		}
+		return github.NewEnterpriseServer(cfg.BaseURL, cfg.BaseURL, cfg.Token)
+	case forge.KindGitLab:
+		if cfg.BaseURL == "" {
+			return nil, fmt.Errorf("base URL is required for %s", cfg.Kind)
+		}
+		return gitlab.NewServer(cfg.BaseURL, cfg.Token), nil
+	case forge.KindBitbucketServer:
+		if cfg.BaseURL == "" {
+			return nil, fmt.Errorf("base URL is required for %s", cfg.Kind)
+		}
+		return bitbucket.NewServer(cfg.BaseURL, cfg.Token), nil
+	default:
+		return nil, fmt.Errorf("unknown provider kind %q", cfg.Kind)
+	}
+}